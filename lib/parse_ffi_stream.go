@@ -0,0 +1,18 @@
+package libparseFfi
+
+// FindLinesInIndexCollectionStream invokes callback once for every matching
+// line in the index collection, in the same order `FindLinesInIndexCollection`
+// would have returned them, stopping early if callback returns false.
+//
+// `parse_ffi.h` does not (yet) expose a dedicated streaming entry point, so
+// this is implemented in terms of `FindLinesInIndexCollection` rather than a
+// forward-declared extern of unverified ABI; it still materialises the full
+// match list across the cgo boundary, but avoids a second cgo round-trip and
+// lets callers bail out of iteration early.
+func FindLinesInIndexCollectionStream(dir string, query string, style SearchStyle, callback func(line string) bool) {
+	for _, line := range FindLinesInIndexCollection(dir, query, style) {
+		if !callback(line) {
+			return
+		}
+	}
+}
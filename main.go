@@ -11,26 +11,38 @@ import (
 	"github.com/danielgtaylor/huma/v2/adapters/humachi"
 	"github.com/danielgtaylor/huma/v2/humacli"
 	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/denwong47/rockyou2024/src/host/auth"
+	"github.com/denwong47/rockyou2024/src/host/cmd"
 	configModule "github.com/denwong47/rockyou2024/src/host/config"
 	"github.com/denwong47/rockyou2024/src/host/consts"
 	"github.com/denwong47/rockyou2024/src/host/index"
 	"github.com/denwong47/rockyou2024/src/host/interfaces"
+	"github.com/denwong47/rockyou2024/src/host/jobs"
+	"github.com/denwong47/rockyou2024/src/host/metrics"
 )
 
 func main() {
 	// Create a CLI app which takes a port option.
 	cli := humacli.New(func(hooks humacli.Hooks, options *configModule.Options) {
 		// TODO - Implement CLI flags for setting the port and other options
+		if options.JWTSecret == "" {
+			log.Fatalf("--jwt-secret must not be empty; refusing to start with a public (empty) signing key.")
+		}
+
 		router := chi.NewMux()
+		router.Use(metrics.Middleware)
+		router.Use(auth.Middleware([]byte(options.JWTSecret)))
+
 		config := huma.DefaultConfig("PasswordDumpSearch", "0.1.0")
-		// config.Components.SecuritySchemes = map[string]*huma.SecurityScheme{
-		// 	"BearerAuth": {
-		// 		Type:         "http",
-		// 		Scheme:       "bearer",
-		// 		BearerFormat: "base64",
-		// 	},
-		// }
+		config.Components.SecuritySchemes = map[string]*huma.SecurityScheme{
+			"BearerAuth": {
+				Type:         "http",
+				Scheme:       "bearer",
+				BearerFormat: "JWT",
+			},
+		}
 		api := humachi.New(router, config)
 		api.OpenAPI().Info.Title = "Password Dump Search API"
 		api.OpenAPI().Info.Description = consts.AppDescription + "\n\n" + consts.AppDisclaimer
@@ -42,17 +54,91 @@ func main() {
 		log.Printf("Starting Query service...\n")
 		log.Printf("Search operations will be limited to %v.\n", options.Timeout)
 
+		cache, err := index.NewCache(consts.DefaultCacheSize)
+		if !err.IsEmpty() {
+			log.Fatalf("Failed to create cache: %v", err.Message)
+		}
+
 		huma.Register(api, huma.Operation{
 			Method:      http.MethodGet,
 			Path:        "/search",
 			Summary:     "Search",
 			Description: `Search for passwords in the RockYou2024 dataset.`,
 			Errors:      []int{200, 400, 408, 422},
-		}, interfaces.HostErrorWrapper(interfaces.Query))
+			Security:    []map[string][]string{{"BearerAuth": {}}},
+		}, interfaces.HostErrorWrapper(interfaces.UsesCache(cache, interfaces.Query)))
+
+		huma.Register(api, huma.Operation{
+			Method:      http.MethodGet,
+			Path:        "/search/stream",
+			Summary:     "Search (streaming)",
+			Description: `Search for passwords in the RockYou2024 dataset, streaming matches as NDJSON, one match per line.`,
+			Errors:      []int{400, 408, 422},
+			Security:    []map[string][]string{{"BearerAuth": {}}},
+		}, interfaces.HostErrorWrapper(interfaces.QueryStream))
+
+		var broker jobs.Broker
+		if options.AMQPURL != "" {
+			amqpBroker, err := jobs.NewAMQPBroker(options.AMQPURL, "trockyou.jobs")
+			if err != nil {
+				log.Fatalf("Failed to connect to AMQP broker: %v", err)
+			}
+			broker = amqpBroker
+		} else {
+			broker = jobs.NewMemoryBroker(options.JobQueue)
+		}
+
+		jobManager := jobs.NewManager(broker, cache, options.JobTimeout, options.JobWorkers, options.JobRetention)
+		jobsCtx, cancelJobs := context.WithCancel(context.Background())
+
+		huma.Register(api, huma.Operation{
+			Method:      http.MethodPost,
+			Path:        "/jobs",
+			Summary:     "Submit async search job",
+			Description: `Enqueue a search that may outlive the request timeout, returning a job ID to poll.`,
+			Errors:      []int{200, 400, 422},
+			Security:    []map[string][]string{{"BearerAuth": {}}},
+		}, interfaces.HostErrorWrapper(interfaces.UsesManager(jobManager, interfaces.SubmitJob)))
+
+		huma.Register(api, huma.Operation{
+			Method:      http.MethodGet,
+			Path:        "/jobs/{id}",
+			Summary:     "Get async search job",
+			Description: `Return the status of a job, and its results once done.`,
+			Errors:      []int{200, 404},
+			Security:    []map[string][]string{{"BearerAuth": {}}},
+		}, interfaces.HostErrorWrapper(interfaces.UsesManager(jobManager, interfaces.GetJob)))
+
+		router.Get("/jobs/{id}/stream", interfaces.JobStreamHandler(jobManager))
+
+		warmingManager := index.NewWarmingManager(
+			configModule.DefaultIndexPath,
+			cache,
+			options.CacheWarmJournal,
+			options.CacheWarmTopN,
+			options.CacheTTL,
+		)
+		warmingCtx, cancelWarming := context.WithCancel(context.Background())
+
+		huma.Register(api, huma.Operation{
+			Method:      http.MethodPost,
+			Path:        "/admin/warm",
+			Summary:     "Warm the search cache",
+			Description: `Preload the search cache with the given queries.`,
+			Errors:      []int{200, 400, 422},
+			Security:    []map[string][]string{{"BearerAuth": {}}},
+		}, interfaces.HostErrorWrapper(interfaces.UsesWarmingManager(warmingManager, interfaces.AdminWarm)))
+
+		// `/metrics` is served unauthenticated on the main mux, alongside (but
+		// outside) the bearer-protected `router`, so Prometheus can scrape it
+		// without a token.
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		mux.Handle("/", router)
 
 		server := http.Server{
 			Addr:    fmt.Sprintf("%s:%d", options.Host, options.Port),
-			Handler: router,
+			Handler: mux,
 		}
 
 		if exists, err := index.Exists(configModule.DefaultIndexPath); exists {
@@ -64,12 +150,30 @@ func main() {
 		}
 
 		hooks.OnStart(func() {
+			if err := jobManager.Start(jobsCtx); err != nil {
+				log.Fatalf("Failed to start job manager: %v", err)
+			}
+
+			if err := warmingManager.Replay(); !err.IsEmpty() {
+				log.Printf("Failed to replay the cache warming journal: %s", err.Message)
+			}
+			go warmingManager.Start(warmingCtx, options.CacheRefreshInterval)
+
 			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 				log.Fatalf("Failed to start server: %v", err)
 			}
 		})
 
 		hooks.OnStop(func() {
+			cancelJobs()
+			cancelWarming()
+
+			if closer, ok := broker.(*jobs.AMQPBroker); ok {
+				if err := closer.Close(); err != nil {
+					log.Printf("Failed to close AMQP broker: %v", err)
+				}
+			}
+
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
 			if err := server.Shutdown(ctx); err != nil {
@@ -78,5 +182,7 @@ func main() {
 		})
 	})
 
+	cli.Root().AddCommand(cmd.NewTokenCommand())
+
 	cli.Run()
 }
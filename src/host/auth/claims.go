@@ -0,0 +1,40 @@
+/*
+Package auth provides JWT-based bearer authentication for the host API.
+
+A token carries a username and a `rights` map of HTTP method to allowed path
+globs, e.g. `{"GET": ["/search"], "POST": ["/tokens"]}`. The middleware in
+this package validates the token and enforces those rights against the
+incoming request before it reaches the handler.
+*/
+package auth
+
+import (
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Rights maps an HTTP method to the path globs it is allowed to access.
+//
+// Globs are matched with `path.Match`, e.g. "/jobs/*" allows "/jobs/123" but
+// not "/jobs/123/stream".
+type Rights map[string][]string
+
+// Allows reports whether the rights permit the given method and path.
+func (r Rights) Allows(method string, path string) bool {
+	for _, glob := range r[method] {
+		if matched, err := pathMatch(glob, path); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Claims is the JWT claims embedded in a bearer token issued by this service.
+type Claims struct {
+	jwt.RegisteredClaims
+
+	// Username identifies the caller that the token was issued to.
+	Username string `json:"username"`
+
+	// Rights is the set of HTTP method/path globs the caller is allowed to use.
+	Rights Rights `json:"rights"`
+}
@@ -0,0 +1,30 @@
+package auth
+
+import "testing"
+
+func TestRightsAllows(t *testing.T) {
+	rights := Rights{
+		"GET":  {"/search", "/jobs/*"},
+		"POST": {"/jobs"},
+	}
+
+	tests := []struct {
+		method   string
+		path     string
+		expected bool
+	}{
+		{"GET", "/search", true},
+		{"GET", "/jobs/123", true},
+		{"GET", "/jobs/123/stream", false},
+		{"GET", "/admin/warm", false},
+		{"POST", "/jobs", true},
+		{"POST", "/search", false},
+		{"DELETE", "/search", false},
+	}
+
+	for _, test := range tests {
+		if got := rights.Allows(test.method, test.path); got != test.expected {
+			t.Errorf("Allows(%q, %q) = %v, want %v", test.method, test.path, got, test.expected)
+		}
+	}
+}
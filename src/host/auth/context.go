@@ -0,0 +1,22 @@
+package auth
+
+import "context"
+
+// contextKey is an unexported type to avoid collisions with context keys
+// defined in other packages.
+type contextKey string
+
+// claimsContextKey is the context key under which the validated `Claims` for
+// the current request are stored by `Middleware`.
+const claimsContextKey contextKey = "auth.claims"
+
+// ClaimsFromContext returns the `Claims` injected by `Middleware`, if any.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*Claims)
+	return claims, ok
+}
+
+// withClaims returns a copy of ctx carrying the given claims.
+func withClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey, claims)
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// pathMatch wraps `path.Match` so callers don't need to import `path` for a
+// single call site.
+func pathMatch(glob string, name string) (bool, error) {
+	return path.Match(glob, name)
+}
+
+// writeUnauthorized writes a minimal JSON error body, mirroring the
+// "Content-Type: application/json" convention used by `errorMessages.HostError`.
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	w.Write([]byte(`{"title":"Unauthorized","detail":"` + message + `"}`))
+}
+
+// Middleware returns a `chi`-compatible middleware that validates the
+// `Authorization: Bearer <token>` header against secret, enforces the
+// token's rights for the request's method and path, and injects the
+// resulting `Claims` into the request context for downstream handlers such
+// as `interfaces.Query` to consume via `ClaimsFromContext`.
+func Middleware(secret []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+
+			tokenString, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || tokenString == "" {
+				writeUnauthorized(w, "missing bearer token")
+				return
+			}
+
+			claims, err := ParseToken(secret, tokenString)
+			if err != nil {
+				writeUnauthorized(w, "invalid or expired token")
+				return
+			}
+
+			if !claims.Rights.Allows(r.Method, r.URL.Path) {
+				writeUnauthorized(w, "token does not grant access to this resource")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(withClaims(r.Context(), claims)))
+		})
+	}
+}
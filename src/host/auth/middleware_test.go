@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareAcceptsValidTokenWithinRights(t *testing.T) {
+	secret := []byte("test-secret")
+	token, err := IssueToken(secret, "alice", Rights{"GET": {"/search"}}, time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken returned an error: %v", err)
+	}
+
+	var claimsSeen *Claims
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claimsSeen, _ = ClaimsFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/search", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	Middleware(secret)(next).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	if claimsSeen == nil || claimsSeen.Username != "alice" {
+		t.Error("Middleware did not inject the token's Claims into the request context")
+	}
+}
+
+func TestMiddlewareRejectsMissingToken(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("next handler should not be called for a missing token")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/search", nil)
+	rec := httptest.NewRecorder()
+
+	Middleware([]byte("test-secret"))(next).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddlewareRejectsTokenOutsideRights(t *testing.T) {
+	secret := []byte("test-secret")
+	token, err := IssueToken(secret, "alice", Rights{"GET": {"/search"}}, time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken returned an error: %v", err)
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("next handler should not be called for a right the token does not grant")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/jobs", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	Middleware(secret)(next).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
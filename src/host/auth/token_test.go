@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIssueTokenParseTokenRoundTrip(t *testing.T) {
+	secret := []byte("test-secret")
+	rights := Rights{"GET": {"/search"}}
+
+	token, err := IssueToken(secret, "alice", rights, time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken returned an error: %v", err)
+	}
+
+	claims, err := ParseToken(secret, token)
+	if err != nil {
+		t.Fatalf("ParseToken returned an error: %v", err)
+	}
+
+	if claims.Username != "alice" {
+		t.Errorf("Username = %q, want %q", claims.Username, "alice")
+	}
+
+	if !claims.Rights.Allows("GET", "/search") {
+		t.Errorf("Rights round-tripped through the token do not allow GET /search")
+	}
+}
+
+func TestParseTokenRejectsWrongSecret(t *testing.T) {
+	token, err := IssueToken([]byte("correct-secret"), "alice", Rights{}, time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken returned an error: %v", err)
+	}
+
+	if _, err := ParseToken([]byte("wrong-secret"), token); err == nil {
+		t.Error("ParseToken did not reject a token signed with a different secret")
+	}
+}
+
+func TestParseTokenRejectsExpiredToken(t *testing.T) {
+	secret := []byte("test-secret")
+
+	token, err := IssueToken(secret, "alice", Rights{}, -time.Hour)
+	if err != nil {
+		t.Fatalf("IssueToken returned an error: %v", err)
+	}
+
+	if _, err := ParseToken(secret, token); err == nil {
+		t.Error("ParseToken did not reject an expired token")
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Package cmd holds `cobra` subcommands layered on top of the `humacli` root
+command set up in `main.go`.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/denwong47/rockyou2024/src/host/auth"
+)
+
+// NewTokenCommand returns the `token` command group, currently offering
+// `token issue` for minting bearer tokens from the CLI.
+func NewTokenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage API bearer tokens.",
+	}
+
+	cmd.AddCommand(newTokenIssueCommand())
+
+	return cmd
+}
+
+// newTokenIssueCommand returns the `token issue` command.
+func newTokenIssueCommand() *cobra.Command {
+	var (
+		username string
+		secret   string
+		ttl      time.Duration
+		rights   []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issue a new bearer token for a user.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if secret == "" {
+				return fmt.Errorf("--secret must not be empty; an empty signing key is publicly known")
+			}
+
+			parsedRights, err := parseRights(rights)
+			if err != nil {
+				return err
+			}
+
+			token, err := auth.IssueToken([]byte(secret), username, parsedRights, ttl)
+			if err != nil {
+				return fmt.Errorf("failed to issue token: %w", err)
+			}
+
+			fmt.Println(token)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", "Username to issue the token for.")
+	cmd.Flags().StringVar(&secret, "secret", "", "HS256 signing secret; must match the server's --jwt-secret.")
+	cmd.Flags().DurationVar(&ttl, "ttl", 24*time.Hour, "Token validity duration.")
+	cmd.Flags().StringArrayVar(&rights, "right", nil, "A `METHOD=glob` pair granting access, e.g. --right GET=/search. Repeatable.")
+
+	cmd.MarkFlagRequired("username")
+	cmd.MarkFlagRequired("secret")
+
+	return cmd
+}
+
+// parseRights turns `METHOD=glob` pairs, as passed via repeated `--right`
+// flags, into an `auth.Rights` map.
+func parseRights(pairs []string) (auth.Rights, error) {
+	rights := make(auth.Rights, len(pairs))
+
+	for _, pair := range pairs {
+		method, glob, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --right %q; expected METHOD=glob", pair)
+		}
+
+		method = strings.ToUpper(method)
+		rights[method] = append(rights[method], glob)
+	}
+
+	return rights, nil
+}
@@ -4,7 +4,19 @@ import "time"
 
 // Options for the CLI.
 type Options struct {
-	Host    string        `doc:"Host to listen on" format:"ipv4" default:"0.0.0.0"`
-	Port    int           `doc:"Port to listen on" short:"p" default:"8888"`
-	Timeout time.Duration `doc:"Timeout for requests in seconds" default:"15s"`
+	Host      string        `doc:"Host to listen on" format:"ipv4" default:"0.0.0.0"`
+	Port      int           `doc:"Port to listen on" short:"p" default:"8888"`
+	Timeout   time.Duration `doc:"Timeout for requests in seconds" default:"15s"`
+	JWTSecret string        `doc:"HS256 secret used to sign and validate bearer tokens" default:"" name:"jwt-secret"`
+
+	JobTimeout   time.Duration `doc:"Timeout for a single async job, independent of the request timeout" default:"5m" name:"job-timeout"`
+	JobWorkers   int           `doc:"Number of worker goroutines processing async jobs" default:"4" name:"job-workers"`
+	JobQueue     int           `doc:"Depth of the in-process job queue; ignored when --amqp-url is set" default:"64" name:"job-queue"`
+	AMQPURL      string        `doc:"AMQP URL for the shared job broker; when empty, an in-process broker is used" default:"" name:"amqp-url"`
+	JobRetention time.Duration `doc:"How long a settled job's status and results are kept in memory before being evicted" default:"10m" name:"job-retention"`
+
+	CacheWarmJournal     string        `doc:"Path to the cache warming journal, replayed at startup and persisted on shutdown" default:"cache_warm.json" name:"cache-warm-journal"`
+	CacheWarmTopN        int           `doc:"Number of hottest queries to persist to the cache warming journal" default:"100" name:"cache-warm-top-n"`
+	CacheRefreshInterval time.Duration `doc:"How often to re-run cached queries whose TTL has expired" default:"5m" name:"cache-refresh-interval"`
+	CacheTTL             time.Duration `doc:"How long a cached entry may go without being refreshed" default:"30m" name:"cache-ttl"`
 }
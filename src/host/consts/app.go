@@ -1,5 +1,9 @@
 package consts
 
+// DefaultCacheSize is the number of distinct queries kept in the LRU result
+// cache shared between the synchronous and async job search paths.
+const DefaultCacheSize = 1024
+
 const AppDescription = `# Password Dump Query API
 
 This API provides a way to query the RockYou2024 password dump. The dump is
@@ -0,0 +1,75 @@
+package index
+
+import (
+	"sync"
+	"time"
+)
+
+// CacheEntry wraps a cached result set with the metadata needed by
+// `WarmingManager` to decide what to persist and what to refresh, going
+// beyond the pure-LRU eviction the underlying `lru.Cache` provides on its
+// own.
+//
+// Results and InsertionTime are set once at construction and never mutated
+// afterwards, so they're safe to read without locking. hitCount and
+// lastAccess are updated on every concurrent cache hit while also being read
+// from `WarmingManager`'s background goroutine, so they're guarded by mu and
+// only reachable through RecordHit/HitCount/LastAccess/SetHitCount.
+type CacheEntry struct {
+	Results []string
+
+	// InsertionTime is when the entry was added to the cache; `Refresh`
+	// replaces an entry with a new one, so after a refresh this is the time
+	// of that refresh.
+	InsertionTime time.Time
+
+	mu         sync.Mutex
+	hitCount   int
+	lastAccess time.Time
+}
+
+// newCacheEntry creates a `CacheEntry` for results that have just been
+// computed.
+func newCacheEntry(results []string) *CacheEntry {
+	now := time.Now()
+	return &CacheEntry{
+		Results:       results,
+		InsertionTime: now,
+		lastAccess:    now,
+	}
+}
+
+// RecordHit marks the entry as read via `Get`, incrementing HitCount and
+// bumping LastAccess to now.
+func (e *CacheEntry) RecordHit() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.hitCount++
+	e.lastAccess = time.Now()
+}
+
+// HitCount returns the number of times the entry has been read via `Get`.
+func (e *CacheEntry) HitCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.hitCount
+}
+
+// SetHitCount overwrites the entry's hit count, e.g. when `Refresh` carries
+// it over to a newly-fetched replacement entry.
+func (e *CacheEntry) SetHitCount(n int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.hitCount = n
+}
+
+// LastAccess returns when the entry was last read via `Get`.
+func (e *CacheEntry) LastAccess() time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.lastAccess
+}
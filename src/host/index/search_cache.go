@@ -3,8 +3,10 @@ package index
 import (
 	"errors"
 	"log"
+	"time"
 
 	errorMessages "github.com/denwong47/rockyou2024/src/host/errors"
+	"github.com/denwong47/rockyou2024/src/host/metrics"
 	lru "github.com/hashicorp/golang-lru/v2"
 )
 
@@ -15,11 +17,11 @@ type CacheKey struct {
 }
 
 // An alias for the LRU cache type.
-type CacheType = lru.Cache[CacheKey, []string]
+type CacheType = lru.Cache[CacheKey, *CacheEntry]
 
 // `NewCache` creates a new LRU cache with the specified size.
 func NewCache(size int) (*CacheType, errorMessages.HostError) {
-	if cache, err := lru.New[CacheKey, []string](size); err != nil {
+	if cache, err := lru.New[CacheKey, *CacheEntry](size); err != nil {
 		return nil, errorMessages.FromError(
 			err,
 			"Failed to create a new cache.",
@@ -34,25 +36,42 @@ func NewCache(size int) (*CacheType, errorMessages.HostError) {
 // Find the lines in the index collection, and cache the results.
 func FindLinesInIndexCollectionCached(dir string, query string, style SearchStyle, cache *CacheType) ([]string, errorMessages.HostError) {
 	searchString := QueryAsSearchString(query, style)
+	key := CacheKey{Query: searchString, Style: style}
+	start := time.Now()
 
 	// Check if the cache contains the query.
-	if result, ok := cache.Get(CacheKey{Query: searchString, Style: style}); ok {
-		log.Printf("Cache hit for query `%s` using `%+v`, returning %d results.", searchString, style, len(result))
-		return result, errorMessages.EmptyError()
+	if entry, ok := cache.Get(key); ok {
+		entry.RecordHit()
+
+		metrics.CacheHitsTotal.Inc()
+		metrics.SearchDurationSeconds.WithLabelValues(string(style), "hit").Observe(time.Since(start).Seconds())
+		metrics.SearchRequestsTotal.WithLabelValues(string(style), "success").Inc()
+
+		log.Printf("Cache hit for query `%s` using `%+v`, returning %d results.", searchString, style, len(entry.Results))
+		return entry.Results, errorMessages.EmptyError()
 	}
 
+	metrics.CacheMissesTotal.Inc()
+
 	results, err := FindLinesInIndexCollection(dir, query, style)
 
+	metrics.SearchDurationSeconds.WithLabelValues(string(style), "miss").Observe(time.Since(start).Seconds())
+
 	// If there was an error, return the error.
 	if !err.IsEmpty() {
+		metrics.SearchRequestsTotal.WithLabelValues(string(style), "error").Inc()
 		return nil, err
 	}
 
+	metrics.SearchRequestsTotal.WithLabelValues(string(style), "success").Inc()
+
 	// Add the results to the cache.
-	if cache.Add(CacheKey{Query: searchString, Style: style}, results) {
+	if cache.Add(key, newCacheEntry(results)) {
 		log.Printf("Added query `%s` to the cache using `%+v`, which caused an eviction.", searchString, style)
 	}
 
+	metrics.CacheSize.Set(float64(cache.Len()))
+
 	return results, err
 }
 
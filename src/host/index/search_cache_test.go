@@ -0,0 +1,43 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/denwong47/rockyou2024/src/host/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const testMockIndex = "../../../.tests/mock_index/"
+
+// TestFindLinesInIndexCollectionCachedMetrics asserts that a cache miss
+// followed by a cache hit for the same query increments `CacheMissesTotal`
+// and `CacheHitsTotal` exactly once each.
+func TestFindLinesInIndexCollectionCachedMetrics(t *testing.T) {
+	cache, err := NewCache(8)
+	if !err.IsEmpty() {
+		t.Fatalf("Failed to create cache: %s", err.Message)
+	}
+
+	missesBefore := testutil.ToFloat64(metrics.CacheMissesTotal)
+	hitsBefore := testutil.ToFloat64(metrics.CacheHitsTotal)
+
+	if _, err := FindLinesInIndexCollectionCached(testMockIndex, "password", SearchStyle("strict"), cache); !err.IsEmpty() {
+		t.Fatalf("Unexpected error on cache miss: %s", err.Message)
+	}
+
+	if got, want := testutil.ToFloat64(metrics.CacheMissesTotal), missesBefore+1; got != want {
+		t.Errorf("CacheMissesTotal = %v, want %v", got, want)
+	}
+
+	if _, err := FindLinesInIndexCollectionCached(testMockIndex, "password", SearchStyle("strict"), cache); !err.IsEmpty() {
+		t.Fatalf("Unexpected error on cache hit: %s", err.Message)
+	}
+
+	if got, want := testutil.ToFloat64(metrics.CacheHitsTotal), hitsBefore+1; got != want {
+		t.Errorf("CacheHitsTotal = %v, want %v", got, want)
+	}
+
+	if got, want := testutil.ToFloat64(metrics.CacheMissesTotal), missesBefore+1; got != want {
+		t.Errorf("CacheMissesTotal after hit = %v, want unchanged %v", got, want)
+	}
+}
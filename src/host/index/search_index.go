@@ -5,9 +5,11 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	libparseFfi "github.com/denwong47/rockyou2024/lib"
 	errorMessages "github.com/denwong47/rockyou2024/src/host/errors"
+	"github.com/denwong47/rockyou2024/src/host/metrics"
 )
 
 // Re-export `SearchStyle`, an enum for the search style.
@@ -42,7 +44,9 @@ func QueryAsSearchString(query string, style SearchStyle) string {
 // Re-export the `FindLinesInIndexCollection` function from the `libparseFfi` package,
 // to make it more ergonomic to use.
 func FindLinesInIndexCollection(dir string, query string, style SearchStyle) ([]string, errorMessages.HostError) {
+	start := time.Now()
 	results := libparseFfi.FindLinesInIndexCollection(dir, query, style)
+	metrics.FFICallDurationSeconds.WithLabelValues("find_lines_in_index_collection").Observe(time.Since(start).Seconds())
 
 	if len(results) == 0 {
 		return nil, errorMessages.FromError(
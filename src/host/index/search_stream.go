@@ -0,0 +1,32 @@
+package index
+
+import (
+	"errors"
+	"net/http"
+
+	libparseFfi "github.com/denwong47/rockyou2024/lib"
+	errorMessages "github.com/denwong47/rockyou2024/src/host/errors"
+)
+
+// StreamMatches invokes callback once for every matching line in the index
+// collection, in the same order `FindLinesInIndexCollection` would have
+// returned them. Returning false from callback halts iteration early.
+func StreamMatches(dir string, query string, style SearchStyle, callback func(line string) bool) errorMessages.HostError {
+	matched := false
+
+	libparseFfi.FindLinesInIndexCollectionStream(dir, query, style, func(line string) bool {
+		matched = true
+		return callback(line)
+	})
+
+	if !matched {
+		return errorMessages.FromError(
+			errors.New("`libparseFfi.FindLinesInIndexCollectionStream` returned no results"),
+			"No results found; or an error occurred during the search. Please consult the logs for more information.",
+			http.StatusNotFound,
+			nil,
+		)
+	}
+
+	return errorMessages.EmptyError()
+}
@@ -0,0 +1,173 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	errorMessages "github.com/denwong47/rockyou2024/src/host/errors"
+)
+
+// journalEntry is the on-disk representation of a warmed query, ranked by
+// hit count so that `Persist` can keep only the top entries.
+type journalEntry struct {
+	Query    string      `json:"query"`
+	Style    SearchStyle `json:"style"`
+	HitCount int         `json:"hit_count"`
+}
+
+// WarmingManager keeps a `CacheType` pre-populated: it replays a journal of
+// the hottest queries at startup, periodically re-runs cached queries whose
+// TTL has expired, and persists the current hit counts back to the journal
+// on shutdown.
+type WarmingManager struct {
+	dir         string
+	cache       *CacheType
+	journalPath string
+	topN        int
+	ttl         time.Duration
+}
+
+// NewWarmingManager creates a `WarmingManager` for the index at dir, backed
+// by cache. journalPath is where the top topN queries by hit count are
+// persisted; ttl is how long a cached entry may go without being refreshed.
+func NewWarmingManager(dir string, cache *CacheType, journalPath string, topN int, ttl time.Duration) *WarmingManager {
+	return &WarmingManager{
+		dir:         dir,
+		cache:       cache,
+		journalPath: journalPath,
+		topN:        topN,
+		ttl:         ttl,
+	}
+}
+
+// Replay reads the journal, if any, and re-executes each query so the cache
+// starts out pre-populated rather than purely reactive.
+func (m *WarmingManager) Replay() errorMessages.HostError {
+	data, err := os.ReadFile(m.journalPath)
+	if os.IsNotExist(err) {
+		return errorMessages.EmptyError()
+	} else if err != nil {
+		return errorMessages.FromError(err, "Failed to read the cache warming journal.", 500, nil)
+	}
+
+	var entries []journalEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return errorMessages.FromError(err, "Failed to parse the cache warming journal.", 500, nil)
+	}
+
+	for _, entry := range entries {
+		if _, hostErr := FindLinesInIndexCollectionCached(m.dir, entry.Query, entry.Style, m.cache); !hostErr.IsEmpty() {
+			log.Printf("Failed to warm query `%s`: %s", entry.Query, hostErr.Message)
+		}
+	}
+
+	log.Printf("Replayed %d queries from the cache warming journal at %s.", len(entries), m.journalPath)
+
+	return errorMessages.EmptyError()
+}
+
+// Persist writes the topN hottest cache entries, by hit count, to the
+// journal.
+func (m *WarmingManager) Persist() errorMessages.HostError {
+	entries := make([]journalEntry, 0, len(m.cache.Keys()))
+
+	for _, key := range m.cache.Keys() {
+		if entry, ok := m.cache.Peek(key); ok {
+			entries = append(entries, journalEntry{
+				Query:    key.Query,
+				Style:    key.Style,
+				HitCount: entry.HitCount(),
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].HitCount > entries[j].HitCount
+	})
+
+	if len(entries) > m.topN {
+		entries = entries[:m.topN]
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return errorMessages.FromError(err, "Failed to encode the cache warming journal.", 500, nil)
+	}
+
+	if err := os.WriteFile(m.journalPath, data, 0o644); err != nil {
+		return errorMessages.FromError(err, "Failed to write the cache warming journal.", 500, nil)
+	}
+
+	return errorMessages.EmptyError()
+}
+
+// Refresh re-executes any cached query whose entry has not been inserted or
+// refreshed within the configured TTL, keeping hot entries from growing
+// stale.
+func (m *WarmingManager) Refresh() {
+	now := time.Now()
+
+	for _, key := range m.cache.Keys() {
+		entry, ok := m.cache.Peek(key)
+		if !ok || now.Sub(entry.InsertionTime) < m.ttl {
+			continue
+		}
+
+		results, err := FindLinesInIndexCollection(m.dir, key.Query, key.Style)
+		if !err.IsEmpty() {
+			log.Printf("Failed to refresh query `%s`: %s", key.Query, err.Message)
+			continue
+		}
+
+		refreshed := newCacheEntry(results)
+		refreshed.SetHitCount(entry.HitCount())
+		m.cache.Add(key, refreshed)
+	}
+}
+
+// Start runs the periodic refresh loop at the given interval until ctx is
+// done, at which point it persists the journal once more before returning.
+func (m *WarmingManager) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := m.Persist(); !err.IsEmpty() {
+				log.Printf("Failed to persist the cache warming journal on shutdown: %s", err.Message)
+			}
+			return
+		case <-ticker.C:
+			m.Refresh()
+		}
+	}
+}
+
+// Warm eagerly caches the given queries, e.g. from `POST /admin/warm`. A
+// query that fails to warm (e.g. a 404 for a query with no matches) is
+// logged and does not stop the rest of the batch from being attempted. Warm
+// returns how many of queries were successfully cached.
+func (m *WarmingManager) Warm(queries []WarmQuery) int {
+	warmed := 0
+
+	for _, query := range queries {
+		if _, err := FindLinesInIndexCollectionCached(m.dir, query.Query, query.Style, m.cache); !err.IsEmpty() {
+			log.Printf("Failed to warm query `%s`: %s", query.Query, err.Message)
+			continue
+		}
+		warmed++
+	}
+
+	return warmed
+}
+
+// WarmQuery is a single query to preload, as accepted by `Warm`.
+type WarmQuery struct {
+	Query string      `json:"query"`
+	Style SearchStyle `json:"style"`
+}
@@ -0,0 +1,39 @@
+package interfaces
+
+import (
+	"context"
+
+	errorMessages "github.com/denwong47/rockyou2024/src/host/errors"
+	"github.com/denwong47/rockyou2024/src/host/index"
+)
+
+// AdminWarmRequestBody is the request body for the cache warming endpoint.
+type AdminWarmRequestBody struct {
+	Queries []index.WarmQuery `json:"queries" doc:"The queries to preload into the cache."`
+}
+
+// AdminWarmRequest is the request object for the cache warming endpoint.
+type AdminWarmRequest struct {
+	Body AdminWarmRequestBody `json:"body" doc:"The body of the request."`
+}
+
+// AdminWarmResponse is the response object for the cache warming endpoint.
+type AdminWarmResponse struct {
+	Body struct {
+		Warmed int `json:"warmed" doc:"The number of queries successfully preloaded."`
+	} `json:"body" doc:"The body of the response."`
+}
+
+// AdminWarm preloads the cache with the given queries, returning how many of
+// them were actually cached; a query with no matches is skipped rather than
+// failing the whole request.
+func AdminWarm(
+	ctx context.Context,
+	manager *index.WarmingManager,
+	input *AdminWarmRequest,
+) (*AdminWarmResponse, errorMessages.HostError) {
+	response := &AdminWarmResponse{}
+	response.Body.Warmed = manager.Warm(input.Body.Queries)
+
+	return response, errorMessages.EmptyError()
+}
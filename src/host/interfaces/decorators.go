@@ -5,14 +5,24 @@ import (
 
 	errorMessages "github.com/denwong47/rockyou2024/src/host/errors"
 	index "github.com/denwong47/rockyou2024/src/host/index"
+	"github.com/denwong47/rockyou2024/src/host/jobs"
 )
 
 // Short Hand for the EndpointHandler function signature.
 type EndpointHandler[T, R, E any] func(ctx context.Context, input *T) (*R, E)
 
-// EndpointHandler is a function that handles an endpoint.
+// EndpointHandlerWithManager is a function that handles an endpoint backed
+// by the async job `jobs.Manager`.
+type EndpointHandlerWithManager[T, R, E any] func(ctx context.Context, manager *jobs.Manager, input *T) (*R, E)
+
+// EndpointHandlerWithCache is a function that handles an endpoint backed by
+// the shared `index.CacheType`.
 type EndpointHandlerWithCache[T, R, E any] func(ctx context.Context, cache *index.CacheType, input *T) (*R, E)
 
+// EndpointHandlerWithWarmingManager is a function that handles an endpoint
+// backed by the `index.WarmingManager`.
+type EndpointHandlerWithWarmingManager[T, R, E any] func(ctx context.Context, manager *index.WarmingManager, input *T) (*R, E)
+
 // HostErrorWrapper wraps a function that returns a HostError and converts it to a
 // standard error if it is not empty.
 func HostErrorWrapper[Q, R any](
@@ -27,7 +37,27 @@ func HostErrorWrapper[Q, R any](
 	}
 }
 
-// Decorator to transform a `EndpointHandlerWithAuthManager` into a `EndpointHandler`.
+// Decorator to transform a `EndpointHandlerWithManager` into a `EndpointHandler`.
+func UsesManager[T, R, E any](
+	manager *jobs.Manager,
+	handler EndpointHandlerWithManager[T, R, E],
+) EndpointHandler[T, R, E] {
+	return func(ctx context.Context, input *T) (*R, E) {
+		return handler(ctx, manager, input)
+	}
+}
+
+// Decorator to transform a `EndpointHandlerWithWarmingManager` into a `EndpointHandler`.
+func UsesWarmingManager[T, R, E any](
+	manager *index.WarmingManager,
+	handler EndpointHandlerWithWarmingManager[T, R, E],
+) EndpointHandler[T, R, E] {
+	return func(ctx context.Context, input *T) (*R, E) {
+		return handler(ctx, manager, input)
+	}
+}
+
+// Decorator to transform a `EndpointHandlerWithCache` into a `EndpointHandler`.
 func UsesCache[T, R, E any](
 	cache *index.CacheType,
 	handler EndpointHandlerWithCache[T, R, E],
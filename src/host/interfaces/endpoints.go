@@ -5,12 +5,16 @@ import (
 	"log"
 	"time"
 
+	"github.com/denwong47/rockyou2024/src/host/auth"
 	"github.com/denwong47/rockyou2024/src/host/config"
 	errorMessages "github.com/denwong47/rockyou2024/src/host/errors"
 	index "github.com/denwong47/rockyou2024/src/host/index"
 )
 
-// Query is the main query endpoint.
+// Query is the main query endpoint. It paginates matches from the index
+// collection, served out of the shared `index.CacheType` (warmed by
+// `index.WarmingManager` and instrumented by the `metrics` cache counters) so
+// repeat queries don't re-run the full FFI search.
 func Query(
 	ctx context.Context,
 	cache *index.CacheType,
@@ -20,7 +24,11 @@ func Query(
 		input.Style = index.SearchStyle("fuzzy")
 	}
 
-	log.Printf("Searching for '%s' with style '%s'...", input.Query, input.Style)
+	if claims, ok := auth.ClaimsFromContext(ctx); ok {
+		log.Printf("Searching for '%s' with style '%s' on behalf of '%s'...", input.Query, input.Style, claims.Username)
+	} else {
+		log.Printf("Searching for '%s' with style '%s'...", input.Query, input.Style)
+	}
 
 	if results, err := index.FindLinesInIndexCollectionPaginated(
 		config.DefaultIndexPath,
@@ -0,0 +1,186 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	errorMessages "github.com/denwong47/rockyou2024/src/host/errors"
+	index "github.com/denwong47/rockyou2024/src/host/index"
+	"github.com/denwong47/rockyou2024/src/host/jobs"
+)
+
+// JobSubmitRequestBody is the request body for the job submission endpoint,
+// mirroring `QueryRequest` but carried as JSON rather than query parameters.
+type JobSubmitRequestBody struct {
+	Query  string            `json:"query" required:"true" maxLength:"256" example:"myPassword" doc:"The password pattern to search for."`
+	Style  index.SearchStyle `json:"style" doc:"The search style to use; allowed values are 'fuzzy', 'case-insensitive' and 'strict'. Defaults to 'fuzzy'." default:"fuzzy"`
+	Offset int               `json:"offset" doc:"The offset to start the search from." default:"0"`
+	Limit  int               `json:"limit" doc:"The maximum number of results to return." default:"500"`
+}
+
+// JobSubmitRequest is the request object for the job submission endpoint.
+type JobSubmitRequest struct {
+	Body JobSubmitRequestBody `json:"body" doc:"The search to run asynchronously."`
+}
+
+// JobSubmitResponseBody is the response body for the job submission endpoint.
+type JobSubmitResponseBody struct {
+	JobID string `json:"job_id" doc:"The ID of the newly created job; poll GET /jobs/{id} for its status."`
+}
+
+// JobSubmitResponse is the response object for the job submission endpoint.
+type JobSubmitResponse struct {
+	Body JobSubmitResponseBody `json:"body" doc:"The body of the response."`
+}
+
+// JobStatusRequest is the request object for the job status endpoint.
+type JobStatusRequest struct {
+	ID string `path:"id" doc:"The ID of the job to retrieve."`
+}
+
+// JobStatusResponseBody is the response body for the job status endpoint.
+type JobStatusResponseBody struct {
+	JobID   string      `json:"job_id" doc:"The ID of the job."`
+	Status  jobs.Status `json:"status" doc:"The current status of the job."`
+	Results []string    `json:"results,omitempty" doc:"The results of the search, present once the job is done."`
+	Error   string      `json:"error,omitempty" doc:"The error encountered while running the job, if any."`
+}
+
+// JobStatusResponse is the response object for the job status endpoint.
+type JobStatusResponse struct {
+	Body JobStatusResponseBody `json:"body" doc:"The body of the response."`
+}
+
+// SubmitJob enqueues a search as an asynchronous job and returns its ID.
+func SubmitJob(
+	ctx context.Context,
+	manager *jobs.Manager,
+	input *JobSubmitRequest,
+) (*JobSubmitResponse, errorMessages.HostError) {
+	if input.Body.Style == "" {
+		input.Body.Style = index.SearchStyle("fuzzy")
+	}
+
+	job, err := manager.Submit(ctx, jobs.Request{
+		Query:  input.Body.Query,
+		Style:  input.Body.Style,
+		Offset: input.Body.Offset,
+		Limit:  input.Body.Limit,
+	})
+	if !err.IsEmpty() {
+		return &JobSubmitResponse{}, err
+	}
+
+	return &JobSubmitResponse{
+		Body: JobSubmitResponseBody{JobID: job.ID},
+	}, errorMessages.EmptyError()
+}
+
+// GetJob returns the current status (and, once done, results) of a job.
+func GetJob(
+	ctx context.Context,
+	manager *jobs.Manager,
+	input *JobStatusRequest,
+) (*JobStatusResponse, errorMessages.HostError) {
+	job, ok := manager.Get(input.ID)
+	if !ok {
+		return &JobStatusResponse{}, errorMessages.FromError(
+			nil,
+			"No job found with the given ID.",
+			404,
+			nil,
+		)
+	}
+
+	body := JobStatusResponseBody{
+		JobID:  job.ID,
+		Status: job.Status,
+	}
+
+	if job.Result != nil {
+		body.Results = job.Result.Results
+		body.Error = job.Result.Error
+	}
+
+	return &JobStatusResponse{Body: body}, errorMessages.EmptyError()
+}
+
+// JobStreamHandler returns a plain `chi` handler (rather than a `huma`
+// operation, since Server-Sent Events don't fit huma's request/response
+// model) that streams a job's progress as SSE events, as `manager` publishes
+// them, until it settles.
+func JobStreamHandler(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		job, ok := manager.Get(id)
+		if !ok {
+			http.Error(w, "no job found with the given ID", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		writeEvent := func(name string, payload any) {
+			data, _ := json.Marshal(payload)
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+			flusher.Flush()
+		}
+
+		// The job may already have settled before the Get above; emit its
+		// current state immediately rather than subscribing to events that
+		// have already been published.
+		if job.Status == jobs.StatusDone || job.Status == jobs.StatusError {
+			writeEvent("result", job)
+			return
+		}
+
+		events, unsubscribe := manager.Subscribe(id)
+		defer unsubscribe()
+
+		// The job may have settled in the window between the Get above and
+		// Subscribe; re-check now so we don't wait on an event that already
+		// fired before we started listening.
+		if job, ok := manager.Get(id); ok && (job.Status == jobs.StatusDone || job.Status == jobs.StatusError) {
+			writeEvent("result", job)
+			return
+		}
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+
+				name := "status"
+				switch {
+				case event.Line != "":
+					name = "match"
+				case event.Status == jobs.StatusDone || event.Status == jobs.StatusError:
+					name = "result"
+				}
+
+				writeEvent(name, event)
+
+				if name == "result" {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/denwong47/rockyou2024/src/host/auth"
+	"github.com/denwong47/rockyou2024/src/host/config"
+	errorMessages "github.com/denwong47/rockyou2024/src/host/errors"
+	index "github.com/denwong47/rockyou2024/src/host/index"
+)
+
+// SearchStreamRequest is the request object for the streaming search endpoint.
+// It mirrors `QueryRequest`, minus `Offset`/`Limit`, since the whole point of
+// streaming is to let the client decide how many results it wants by closing
+// the connection rather than paginating.
+type SearchStreamRequest struct {
+	Query string            `query:"query" required:"true" maxLength:"256" example:"myPassword" doc:"The password pattern to search for."`
+	Style index.SearchStyle `query:"style" doc:"The search style to use; allowed values are 'fuzzy', 'case-insensitive' and 'strict'. Defaults to 'fuzzy'." default:"fuzzy"`
+}
+
+// SearchStreamResponse is the response object for the streaming search
+// endpoint. Its `Body` is written to incrementally, one matching line per
+// NDJSON record, rather than being buffered in full before the response is
+// sent.
+type SearchStreamResponse struct {
+	Body huma.StreamResponse
+}
+
+// ndjsonLine is a single record emitted by the streaming search endpoint.
+type ndjsonLine struct {
+	Result string `json:"result" doc:"A single matching line from the index collection."`
+}
+
+// QueryStream is the streaming counterpart of `Query`. It writes matches as
+// newline-delimited JSON (NDJSON), one per line, flushing after every line,
+// so that clients can consume the response incrementally and disconnect
+// early via ctx.Done(), which stops any further lines being written. The
+// search underneath (`index.StreamMatches`) collects the full match list
+// before the first line is written, so neither the time to the first line
+// nor the memory held for the matches is reduced.
+func QueryStream(
+	ctx context.Context,
+	input *SearchStreamRequest,
+) (*SearchStreamResponse, errorMessages.HostError) {
+	if input.Style == "" {
+		input.Style = index.SearchStyle("fuzzy")
+	}
+
+	if claims, ok := auth.ClaimsFromContext(ctx); ok {
+		log.Printf("Streaming search for '%s' with style '%s' on behalf of '%s'...", input.Query, input.Style, claims.Username)
+	} else {
+		log.Printf("Streaming search for '%s' with style '%s'...", input.Query, input.Style)
+	}
+
+	return &SearchStreamResponse{
+		Body: huma.StreamResponse{
+			Body: func(humaCtx huma.Context) {
+				humaCtx.SetHeader("Content-Type", "application/x-ndjson")
+				writer := humaCtx.BodyWriter()
+				flusher, canFlush := writer.(http.Flusher)
+
+				encoder := json.NewEncoder(writer)
+
+				err := index.StreamMatches(config.DefaultIndexPath, input.Query, input.Style, func(line string) bool {
+					select {
+					case <-ctx.Done():
+						return false
+					default:
+					}
+
+					if encodeErr := encoder.Encode(ndjsonLine{Result: line}); encodeErr != nil {
+						return false
+					}
+					if canFlush {
+						flusher.Flush()
+					}
+					return true
+				})
+				if !err.IsEmpty() {
+					log.Printf("Streaming search for '%s' ended with an error: %s", input.Query, err.Message)
+				}
+			},
+		},
+	}, errorMessages.EmptyError()
+}
@@ -0,0 +1,211 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// AMQPBroker is a `Broker` backed by a RabbitMQ queue, allowing multiple
+// `trockyou` instances to share a single job backlog rather than each
+// keeping its own in-process queue.
+//
+// Results are published to a fanout exchange rather than a plain queue: a
+// named queue would only load-balance one result to one competing consumer,
+// but every instance needs to observe every result so that a `GET
+// /jobs/{id}` polled against an instance other than the one whose worker
+// actually ran the job still sees it complete.
+type AMQPBroker struct {
+	conn    *amqp.Connection
+	channel *amqp.Channel
+
+	queueName       string
+	resultsExchange string
+	resultsQueue    string
+
+	// pending maps a job ID to the delivery tag of its unacknowledged
+	// message, so `Ack` can acknowledge by job ID as the `Broker` interface
+	// requires.
+	pending sync.Map
+}
+
+// NewAMQPBroker dials url, declares the job queue named queueName and the
+// results fanout exchange named queueName + ".results", and declares this instance's own exclusive, auto-deleted queue bound to
+// the shared results fanout exchange.
+func NewAMQPBroker(url string, queueName string) (*AMQPBroker, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to AMQP broker: %w", err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open AMQP channel: %w", err)
+	}
+
+	if _, err := channel.QueueDeclare(queueName, true, false, false, false, nil); err != nil {
+		return nil, fmt.Errorf("failed to declare AMQP queue %q: %w", queueName, err)
+	}
+
+	resultsExchange := queueName + ".results"
+	if err := channel.ExchangeDeclare(resultsExchange, "fanout", true, false, false, false, nil); err != nil {
+		return nil, fmt.Errorf("failed to declare AMQP results exchange %q: %w", resultsExchange, err)
+	}
+
+	// durable=false, autoDelete=true, exclusive=true: this instance's own
+	// inbox, torn down when it disconnects rather than accumulating
+	// unconsumed results across restarts.
+	resultsQueue, err := channel.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to declare this instance's AMQP results queue: %w", err)
+	}
+
+	if err := channel.QueueBind(resultsQueue.Name, "", resultsExchange, false, nil); err != nil {
+		return nil, fmt.Errorf("failed to bind AMQP results queue to %q: %w", resultsExchange, err)
+	}
+
+	return &AMQPBroker{
+		conn:            conn,
+		channel:         channel,
+		queueName:       queueName,
+		resultsExchange: resultsExchange,
+		resultsQueue:    resultsQueue.Name,
+	}, nil
+}
+
+// Close releases the underlying AMQP channel and connection.
+func (b *AMQPBroker) Close() error {
+	if err := b.channel.Close(); err != nil {
+		return err
+	}
+	return b.conn.Close()
+}
+
+// Enqueue implements `Broker`.
+func (b *AMQPBroker) Enqueue(ctx context.Context, job *Job) error {
+	body, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job %q: %w", job.ID, err)
+	}
+
+	return b.channel.PublishWithContext(ctx, "", b.queueName, false, false, amqp.Publishing{
+		ContentType: "application/json",
+		MessageId:   job.ID,
+		Body:        body,
+	})
+}
+
+// Consume implements `Broker`. The returned channel is closed when ctx is
+// cancelled or the underlying AMQP delivery channel closes.
+func (b *AMQPBroker) Consume(ctx context.Context) (<-chan *Job, error) {
+	deliveries, err := b.channel.ConsumeWithContext(ctx, b.queueName, "", false, false, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume from AMQP queue %q: %w", b.queueName, err)
+	}
+
+	jobs := make(chan *Job)
+
+	go func() {
+		defer close(jobs)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case delivery, ok := <-deliveries:
+				if !ok {
+					return
+				}
+
+				var job Job
+				if err := json.Unmarshal(delivery.Body, &job); err != nil {
+					log.Printf("Failed to decode job from AMQP delivery: %v", err)
+					delivery.Nack(false, false)
+					continue
+				}
+
+				b.pending.Store(job.ID, delivery.DeliveryTag)
+
+				select {
+				case jobs <- &job:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return jobs, nil
+}
+
+// Ack implements `Broker`.
+func (b *AMQPBroker) Ack(ctx context.Context, jobID string) error {
+	tag, ok := b.pending.LoadAndDelete(jobID)
+	if !ok {
+		return fmt.Errorf("no pending AMQP delivery for job %q", jobID)
+	}
+
+	return b.channel.Ack(tag.(uint64), false)
+}
+
+// PublishResult implements `Broker`, publishing result to the results
+// fanout exchange so every instance sharing this broker, not just the one
+// whose worker produced it, can observe it.
+func (b *AMQPBroker) PublishResult(ctx context.Context, jobID string, result *Result) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode result for job %q: %w", jobID, err)
+	}
+
+	return b.channel.PublishWithContext(ctx, b.resultsExchange, "", false, false, amqp.Publishing{
+		ContentType: "application/json",
+		MessageId:   jobID,
+		Body:        body,
+	})
+}
+
+// ConsumeResults implements `Broker`, reading every result published to the
+// results fanout exchange via this instance's own bound queue. The returned
+// channel is closed when ctx is cancelled or the underlying AMQP delivery
+// channel closes.
+func (b *AMQPBroker) ConsumeResults(ctx context.Context) (<-chan ResultMessage, error) {
+	deliveries, err := b.channel.ConsumeWithContext(ctx, b.resultsQueue, "", true, false, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume from AMQP results queue %q: %w", b.resultsQueue, err)
+	}
+
+	results := make(chan ResultMessage)
+
+	go func() {
+		defer close(results)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case delivery, ok := <-deliveries:
+				if !ok {
+					return
+				}
+
+				var result Result
+				if err := json.Unmarshal(delivery.Body, &result); err != nil {
+					log.Printf("Failed to decode result from AMQP delivery: %v", err)
+					continue
+				}
+
+				select {
+				case results <- ResultMessage{JobID: delivery.MessageId, Result: &result}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return results, nil
+}
@@ -0,0 +1,35 @@
+package jobs
+
+import "context"
+
+// ResultMessage pairs a job ID with the `Result` published for it, as
+// delivered by `Broker.ConsumeResults`.
+type ResultMessage struct {
+	JobID  string
+	Result *Result
+}
+
+// Broker decouples job submission from job execution, so that a `Manager`
+// can be backed by either an in-process queue or a shared external one.
+type Broker interface {
+	// Enqueue submits a job for later execution.
+	Enqueue(ctx context.Context, job *Job) error
+
+	// Consume returns a channel of jobs to execute. The channel is closed
+	// when ctx is cancelled.
+	Consume(ctx context.Context) (<-chan *Job, error)
+
+	// Ack acknowledges that a job has been fully processed, allowing brokers
+	// with redelivery semantics (e.g. AMQP) to discard it from the backlog.
+	Ack(ctx context.Context, jobID string) error
+
+	// PublishResult records the outcome of a job, e.g. for other instances
+	// sharing the same broker to observe.
+	PublishResult(ctx context.Context, jobID string, result *Result) error
+
+	// ConsumeResults returns a channel of results published via
+	// PublishResult by any instance sharing this broker, including this one,
+	// so a `Manager` can keep `Get` in sync with jobs another instance's
+	// worker pool processed. The channel is closed when ctx is cancelled.
+	ConsumeResults(ctx context.Context) (<-chan ResultMessage, error)
+}
@@ -0,0 +1,72 @@
+package jobs
+
+import "sync"
+
+// Event is an incremental update about a `Job`, published as its worker
+// makes progress and consumed via `Manager.Subscribe` so that `JobStreamHandler`
+// can emit SSE events without polling `Manager.Get`.
+//
+// Status is always set: `StatusRunning` while the job runs, including on
+// events carrying a Line. Line is set for a single matching line found while
+// running the job's query; Result is set once the job settles into
+// `StatusDone` or `StatusError`.
+type Event struct {
+	JobID  string  `json:"job_id"`
+	Status Status  `json:"status"`
+	Line   string  `json:"line,omitempty"`
+	Result *Result `json:"result,omitempty"`
+}
+
+// subscriberBuffer is the channel buffer depth for each `Manager.Subscribe`
+// subscription.
+const subscriberBuffer = 16
+
+// subscriberList fans a job's Events out to every channel registered via
+// `Manager.Subscribe`.
+type subscriberList struct {
+	mu   sync.Mutex
+	subs []chan *Event
+}
+
+// add registers and returns a new buffered channel that receives every Event
+// broadcast to this list.
+func (l *subscriberList) add() chan *Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ch := make(chan *Event, subscriberBuffer)
+	l.subs = append(l.subs, ch)
+	return ch
+}
+
+// remove unregisters and closes ch, reporting whether the list is now empty
+// so the caller can drop its entry from `Manager.subscribers`.
+func (l *subscriberList) remove(ch chan *Event) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, sub := range l.subs {
+		if sub == ch {
+			l.subs = append(l.subs[:i], l.subs[i+1:]...)
+			close(ch)
+			break
+		}
+	}
+
+	return len(l.subs) == 0
+}
+
+// broadcast sends event to every registered channel, dropping it for any
+// subscriber too slow to keep up rather than blocking the publishing worker
+// goroutine.
+func (l *subscriberList) broadcast(event *Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, ch := range l.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package jobs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// newJobID generates a random, URL-safe job identifier.
+func newJobID() string {
+	buf := make([]byte, 16)
+
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand.Read does not fail on any platform Go supports; panic
+		// rather than silently handing out a colliding or predictable ID.
+		panic(err)
+	}
+
+	return hex.EncodeToString(buf)
+}
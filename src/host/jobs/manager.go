@@ -0,0 +1,269 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/denwong47/rockyou2024/src/host/config"
+	errorMessages "github.com/denwong47/rockyou2024/src/host/errors"
+	"github.com/denwong47/rockyou2024/src/host/index"
+)
+
+// DefaultWorkers is the worker pool size used when a `Manager` is created
+// without an explicit count.
+const DefaultWorkers = 4
+
+// DefaultRetention is the retention used when a `Manager` is created with a
+// non-positive retention.
+const DefaultRetention = 10 * time.Minute
+
+// sweepInterval is how often `Manager` scans for settled jobs past their
+// retention, independent of retention itself so a long retention doesn't
+// mean a long-lived job sits around for a whole retention period past
+// expiry before being noticed.
+const sweepInterval = time.Minute
+
+// Manager enqueues jobs onto a `Broker` and runs a worker pool that executes
+// them against the shared `index.CacheType`, independent of the HTTP
+// request that submitted them.
+type Manager struct {
+	broker    Broker
+	cache     *index.CacheType
+	timeout   time.Duration
+	workers   int
+	retention time.Duration
+
+	jobs        sync.Map // map[string]*Job
+	subscribers sync.Map // map[string]*subscriberList
+}
+
+// NewManager creates a `Manager` backed by broker, executing jobs against
+// cache with a per-job timeout. Settled jobs (and their subscriber lists)
+// are evicted from memory retention after they settle, so that a `Manager`
+// fronting an arbitrary-query public API does not grow unbounded.
+func NewManager(broker Broker, cache *index.CacheType, timeout time.Duration, workers int, retention time.Duration) *Manager {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+
+	return &Manager{
+		broker:    broker,
+		cache:     cache,
+		timeout:   timeout,
+		workers:   workers,
+		retention: retention,
+	}
+}
+
+// Start launches the worker pool, consuming jobs from the broker until ctx
+// is cancelled, and starts applying results published by whichever instance
+// actually processes a given job (relevant for `AMQPBroker`, where that may
+// not be this instance).
+func (m *Manager) Start(ctx context.Context) error {
+	deliveries, err := m.broker.Consume(ctx)
+	if err != nil {
+		return err
+	}
+
+	results, err := m.broker.ConsumeResults(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < m.workers; i++ {
+		go m.worker(ctx, deliveries)
+	}
+
+	go m.applyResults(ctx, results)
+	go m.sweepLoop(ctx)
+
+	return nil
+}
+
+// sweepLoop periodically evicts settled jobs (and any now-empty subscriber
+// list left behind for them) past m.retention, until ctx is cancelled.
+func (m *Manager) sweepLoop(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.sweep()
+		}
+	}
+}
+
+// sweep deletes every job that settled more than m.retention ago, along with
+// its subscriber list.
+func (m *Manager) sweep() {
+	now := time.Now()
+
+	m.jobs.Range(func(key, value any) bool {
+		job := value.(*Job)
+
+		if settledAt := job.SettledAt(); !settledAt.IsZero() && now.Sub(settledAt) > m.retention {
+			m.jobs.Delete(key)
+			m.subscribers.Delete(key)
+		}
+
+		return true
+	})
+}
+
+func (m *Manager) worker(ctx context.Context, deliveries <-chan *Job) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job, ok := <-deliveries:
+			if !ok {
+				return
+			}
+			m.process(ctx, job)
+		}
+	}
+}
+
+// applyResults keeps this instance's local job state in sync with results
+// published via `Broker.PublishResult`, since `AMQPBroker` load-balances job
+// deliveries across instances: a job submitted against this instance may be
+// processed by another's worker pool.
+func (m *Manager) applyResults(ctx context.Context, results <-chan ResultMessage) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-results:
+			if !ok {
+				return
+			}
+			m.applyResult(msg.JobID, msg.Result)
+		}
+	}
+}
+
+func (m *Manager) applyResult(jobID string, result *Result) {
+	status := StatusDone
+	if result.Error != "" {
+		status = StatusError
+	}
+
+	value, _ := m.jobs.LoadOrStore(jobID, &Job{ID: jobID, CreatedAt: time.Now()})
+	job := value.(*Job)
+
+	job.SetStatusResult(status, result)
+	m.publish(jobID, &Event{JobID: jobID, Status: status, Result: result})
+}
+
+func (m *Manager) process(ctx context.Context, job *Job) {
+	job.SetStatus(StatusRunning)
+	m.jobs.Store(job.ID, job)
+	m.publish(job.ID, &Event{JobID: job.ID, Status: StatusRunning})
+
+	type outcome struct {
+		results []string
+		err     errorMessages.HostError
+	}
+
+	done := make(chan outcome, 1)
+	go func() {
+		results, err := index.FindLinesInIndexCollectionPaginated(
+			config.DefaultIndexPath,
+			job.Request.Query,
+			job.Request.Style,
+			job.Request.Offset,
+			job.Request.Limit,
+			m.cache,
+		)
+		done <- outcome{results: results, err: err}
+	}()
+
+	var status Status
+	var result *Result
+
+	select {
+	case <-time.After(m.timeout):
+		status = StatusError
+		result = &Result{Error: "job timed out", Timestamp: time.Now()}
+	case o := <-done:
+		if !o.err.IsEmpty() {
+			status = StatusError
+			result = &Result{Error: o.err.Message, Timestamp: time.Now()}
+		} else {
+			status = StatusDone
+			result = &Result{Results: o.results, Timestamp: time.Now()}
+
+			for _, line := range o.results {
+				m.publish(job.ID, &Event{JobID: job.ID, Status: StatusRunning, Line: line})
+			}
+		}
+	}
+
+	job.SetStatusResult(status, result)
+	m.jobs.Store(job.ID, job)
+	m.publish(job.ID, &Event{JobID: job.ID, Status: status, Result: result})
+
+	m.broker.PublishResult(ctx, job.ID, result)
+	m.broker.Ack(ctx, job.ID)
+}
+
+// Submit enqueues a new job for req and returns it in `StatusPending`.
+func (m *Manager) Submit(ctx context.Context, req Request) (*Job, errorMessages.HostError) {
+	job := &Job{
+		ID:        newJobID(),
+		Request:   req,
+		Status:    StatusPending,
+		CreatedAt: time.Now(),
+	}
+
+	m.jobs.Store(job.ID, job)
+
+	if err := m.broker.Enqueue(ctx, job); err != nil {
+		return nil, errorMessages.FromError(err, "Failed to enqueue job.", 500, nil)
+	}
+
+	return job, errorMessages.EmptyError()
+}
+
+// Get returns a snapshot of the current state of the job with the given ID,
+// safe to read without racing the worker goroutine that may still be
+// processing it.
+func (m *Manager) Get(id string) (*Job, bool) {
+	value, ok := m.jobs.Load(id)
+	if !ok {
+		return nil, false
+	}
+
+	snapshot := value.(*Job).Snapshot()
+	return &snapshot, true
+}
+
+// Subscribe registers for incremental `Event`s about the job with the given
+// ID until the returned unsubscribe func is called, which also closes the
+// returned channel.
+func (m *Manager) Subscribe(jobID string) (<-chan *Event, func()) {
+	value, _ := m.subscribers.LoadOrStore(jobID, &subscriberList{})
+	list := value.(*subscriberList)
+
+	ch := list.add()
+
+	return ch, func() {
+		if list.remove(ch) {
+			m.subscribers.CompareAndDelete(jobID, list)
+		}
+	}
+}
+
+func (m *Manager) publish(jobID string, event *Event) {
+	if value, ok := m.subscribers.Load(jobID); ok {
+		value.(*subscriberList).broadcast(event)
+	}
+}
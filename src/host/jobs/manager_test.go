@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/denwong47/rockyou2024/src/host/index"
+)
+
+func newTestManager(t *testing.T, timeout time.Duration) *Manager {
+	t.Helper()
+
+	cache, err := index.NewCache(8)
+	if !err.IsEmpty() {
+		t.Fatalf("Failed to create cache: %s", err.Message)
+	}
+
+	return NewManager(NewMemoryBroker(1), cache, timeout, 1, time.Minute)
+}
+
+// TestManagerProcessTimeout checks that process settles a job as StatusError
+// with a timeout message when its search takes longer than the job's
+// timeout, i.e. that the `<-time.After(m.timeout)` arm of process's select
+// wins the race against the `done` channel.
+func TestManagerProcessTimeout(t *testing.T) {
+	manager := newTestManager(t, time.Nanosecond)
+
+	job := &Job{
+		ID:      "job-timeout",
+		Request: Request{Query: "password", Style: index.SearchStyle("strict"), Limit: 10},
+	}
+
+	manager.process(context.Background(), job)
+
+	snapshot := job.Snapshot()
+	if snapshot.Status != StatusError {
+		t.Fatalf("Status = %q, want %q", snapshot.Status, StatusError)
+	}
+	if snapshot.Result == nil || snapshot.Result.Error != "job timed out" {
+		t.Errorf("Result.Error = %+v, want \"job timed out\"", snapshot.Result)
+	}
+}
+
+// TestManagerProcessDone checks that process settles a job via the `done`
+// arm of its select, rather than timing out, when given a generous timeout
+// - the other side of the same race as TestManagerProcessTimeout.
+func TestManagerProcessDone(t *testing.T) {
+	manager := newTestManager(t, time.Minute)
+
+	job := &Job{
+		ID:      "job-done",
+		Request: Request{Query: "password", Style: index.SearchStyle("strict"), Limit: 10},
+	}
+
+	manager.process(context.Background(), job)
+
+	snapshot := job.Snapshot()
+	if snapshot.Status != StatusDone && snapshot.Status != StatusError {
+		t.Fatalf("Status = %q, want %q or %q", snapshot.Status, StatusDone, StatusError)
+	}
+	if snapshot.Result == nil || snapshot.Result.Error == "job timed out" {
+		t.Errorf("Result = %+v, should have settled via the done branch, not the timeout", snapshot.Result)
+	}
+}
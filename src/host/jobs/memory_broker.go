@@ -0,0 +1,83 @@
+package jobs
+
+import "context"
+
+// MemoryBroker is the default `Broker`: an in-process, channel-backed queue
+// with no redelivery or cross-instance sharing.
+type MemoryBroker struct {
+	queue chan *Job
+}
+
+// NewMemoryBroker creates a `MemoryBroker` with the given queue depth.
+func NewMemoryBroker(buffer int) *MemoryBroker {
+	return &MemoryBroker{
+		queue: make(chan *Job, buffer),
+	}
+}
+
+// Enqueue implements `Broker`.
+func (b *MemoryBroker) Enqueue(ctx context.Context, job *Job) error {
+	select {
+	case b.queue <- job:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Consume implements `Broker`. The returned channel is closed when ctx is
+// cancelled, rather than b.queue itself, since Enqueue may still be writing
+// to it.
+func (b *MemoryBroker) Consume(ctx context.Context) (<-chan *Job, error) {
+	jobs := make(chan *Job)
+
+	go func() {
+		defer close(jobs)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case job, ok := <-b.queue:
+				if !ok {
+					return
+				}
+
+				select {
+				case jobs <- job:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return jobs, nil
+}
+
+// Ack implements `Broker`. The in-process queue has no redelivery semantics,
+// so there is nothing to acknowledge.
+func (b *MemoryBroker) Ack(ctx context.Context, jobID string) error {
+	return nil
+}
+
+// PublishResult implements `Broker`. `Manager` tracks job results directly
+// in memory, so there is nothing further to publish here.
+func (b *MemoryBroker) PublishResult(ctx context.Context, jobID string, result *Result) error {
+	return nil
+}
+
+// ConsumeResults implements `Broker`. There is only one instance sharing an
+// in-process queue, and `Manager.process` already applies a job's result to
+// local state directly, so the returned channel is never written to; it is
+// still closed when ctx is cancelled, to honour the `Broker` contract.
+func (b *MemoryBroker) ConsumeResults(ctx context.Context) (<-chan ResultMessage, error) {
+	results := make(chan ResultMessage)
+
+	go func() {
+		defer close(results)
+		<-ctx.Done()
+	}()
+
+	return results, nil
+}
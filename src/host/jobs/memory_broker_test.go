@@ -0,0 +1,85 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestMemoryBrokerEnqueueConsumeAck exercises the basic round trip: a job
+// Enqueued is delivered via Consume, and Ack is a no-op that does not error.
+func TestMemoryBrokerEnqueueConsumeAck(t *testing.T) {
+	broker := NewMemoryBroker(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	job := &Job{ID: "job-1", Request: Request{Query: "foo"}}
+	if err := broker.Enqueue(ctx, job); err != nil {
+		t.Fatalf("Enqueue returned an error: %v", err)
+	}
+
+	deliveries, err := broker.Consume(ctx)
+	if err != nil {
+		t.Fatalf("Consume returned an error: %v", err)
+	}
+
+	select {
+	case delivered := <-deliveries:
+		if delivered.ID != job.ID {
+			t.Errorf("delivered job ID = %q, want %q", delivered.ID, job.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the enqueued job to be delivered")
+	}
+
+	if err := broker.Ack(ctx, job.ID); err != nil {
+		t.Errorf("Ack returned an error: %v", err)
+	}
+}
+
+// TestMemoryBrokerConsumeClosesOnCancel checks that Consume's returned
+// channel is closed once ctx is cancelled, per the Broker interface's
+// contract.
+func TestMemoryBrokerConsumeClosesOnCancel(t *testing.T) {
+	broker := NewMemoryBroker(1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	deliveries, err := broker.Consume(ctx)
+	if err != nil {
+		t.Fatalf("Consume returned an error: %v", err)
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-deliveries:
+		if ok {
+			t.Error("deliveries channel should be closed, not yield a value")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the deliveries channel to close after cancellation")
+	}
+}
+
+// TestMemoryBrokerConsumeResultsClosesOnCancel checks the same contract for
+// ConsumeResults, which MemoryBroker never writes to.
+func TestMemoryBrokerConsumeResultsClosesOnCancel(t *testing.T) {
+	broker := NewMemoryBroker(1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	results, err := broker.ConsumeResults(ctx)
+	if err != nil {
+		t.Fatalf("ConsumeResults returned an error: %v", err)
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-results:
+		if ok {
+			t.Error("results channel should be closed, not yield a value")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the results channel to close after cancellation")
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Package jobs implements an asynchronous long-query job subsystem, sitting
+alongside the synchronous `/search` endpoint for fuzzy searches that may
+exceed `options.Timeout`.
+
+Jobs are enqueued onto a `Broker` (either the default in-process broker, or
+an AMQP-backed one shared between `trockyou` instances) and executed by a
+`Manager`-owned worker pool.
+*/
+package jobs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/denwong47/rockyou2024/src/host/index"
+)
+
+// Status is the lifecycle state of a `Job`.
+type Status string
+
+// The allowed values for `Status`.
+const (
+	StatusPending Status = "pending"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusError   Status = "error"
+)
+
+// Request is the query a `Job` was enqueued to execute.
+type Request struct {
+	Query  string            `json:"query"`
+	Style  index.SearchStyle `json:"style"`
+	Offset int               `json:"offset"`
+	Limit  int               `json:"limit"`
+}
+
+// Result is the outcome of executing a `Job`'s `Request`.
+type Result struct {
+	Results   []string  `json:"results,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Job is a single unit of work tracked by a `Manager` from submission
+// through to completion.
+//
+// Status and Result are mutated by the worker goroutine processing the job
+// while concurrently read by `Manager.Get` and broadcast to subscribers, so
+// mutation must go through `SetStatus`/`SetStatusResult` rather than the
+// fields directly; reading a live (not yet `Snapshot`'d) Job's Status or
+// Result outside of `Manager` is similarly unsafe.
+type Job struct {
+	ID        string    `json:"id"`
+	Request   Request   `json:"request"`
+	Status    Status    `json:"status"`
+	Result    *Result   `json:"result,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	mu sync.Mutex
+
+	// settledAt is the time the job last moved into StatusDone or
+	// StatusError, used by `Manager` to evict settled jobs after they have
+	// sat around for long enough that their caller has had a chance to
+	// retrieve the result.
+	settledAt time.Time
+}
+
+// SetStatus updates the job's status under lock.
+func (j *Job) SetStatus(status Status) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.Status = status
+}
+
+// SetStatusResult updates the job's status and result together under lock,
+// as it settles into `StatusDone` or `StatusError`.
+func (j *Job) SetStatusResult(status Status, result *Result) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.Status = status
+	j.Result = result
+	j.settledAt = time.Now()
+}
+
+// SettledAt returns the time at which the job last settled into
+// `StatusDone` or `StatusError`, or the zero time if it has not yet
+// settled. Used by `Manager` to age out jobs that have sat long enough
+// for their caller to have retrieved the result.
+func (j *Job) SettledAt() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.settledAt
+}
+
+// Snapshot returns a copy of the job, safe to read or marshal to JSON
+// without racing the worker goroutine that mutates Status and Result.
+func (j *Job) Snapshot() Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return Job{
+		ID:        j.ID,
+		Request:   j.Request,
+		Status:    j.Status,
+		Result:    j.Result,
+		CreatedAt: j.CreatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestJobSetStatusResultConcurrent exercises SetStatus/SetStatusResult and
+// Snapshot from many goroutines at once, so `go test -race` can catch a
+// regression back to unguarded field access.
+func TestJobSetStatusResultConcurrent(t *testing.T) {
+	job := &Job{ID: "job-1"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			job.SetStatus(StatusRunning)
+		}()
+
+		go func() {
+			defer wg.Done()
+			job.SetStatusResult(StatusDone, &Result{Results: []string{"a"}})
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_ = job.Snapshot()
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(done)
+
+	snapshot := job.Snapshot()
+	if snapshot.ID != "job-1" {
+		t.Errorf("Snapshot().ID = %q, want %q", snapshot.ID, "job-1")
+	}
+}
+
+// TestJobSettledAt checks that SettledAt is zero until the job settles, and
+// set once it does.
+func TestJobSettledAt(t *testing.T) {
+	job := &Job{ID: "job-1"}
+
+	if !job.SettledAt().IsZero() {
+		t.Error("SettledAt() should be zero before the job settles")
+	}
+
+	job.SetStatus(StatusRunning)
+	if !job.SettledAt().IsZero() {
+		t.Error("SettledAt() should still be zero after SetStatus, which does not settle the job")
+	}
+
+	job.SetStatusResult(StatusDone, &Result{})
+	if job.SettledAt().IsZero() {
+		t.Error("SettledAt() should be non-zero once the job has settled via SetStatusResult")
+	}
+}
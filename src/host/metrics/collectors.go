@@ -0,0 +1,68 @@
+/*
+Package metrics registers the Prometheus collectors used to observe cache
+effectiveness, search latency, and FFI call latency across the host.
+*/
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SearchRequestsTotal counts every search made through
+// FindLinesInIndexCollectionCached, labeled by style and outcome. Streaming
+// searches and cache refreshes do not go through it and are not counted.
+var SearchRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "search_requests_total",
+	Help: "Total number of searches, labeled by style and status.",
+}, []string{"style", "status"})
+
+// SearchDurationSeconds observes how long a search takes, labeled by style
+// and whether it was served from the cache.
+var SearchDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name: "search_duration_seconds",
+	Help: "Duration of FindLinesInIndexCollectionCached, labeled by style and cache hit/miss.",
+}, []string{"style", "cache"})
+
+// CacheSize is the current number of entries held in the search cache.
+var CacheSize = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "cache_size",
+	Help: "Current number of entries in the search cache.",
+})
+
+// CacheHitsTotal counts cache hits in FindLinesInIndexCollectionCached.
+var CacheHitsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "cache_hits_total",
+	Help: "Total number of search cache hits.",
+})
+
+// CacheMissesTotal counts cache misses in FindLinesInIndexCollectionCached.
+var CacheMissesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "cache_misses_total",
+	Help: "Total number of search cache misses.",
+})
+
+// FFICallDurationSeconds observes how long a call across the cgo boundary
+// into libparseFfi takes, labeled by the C function called.
+var FFICallDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name: "ffi_call_duration_seconds",
+	Help: "Duration of calls across the cgo boundary into libparseFfi.",
+}, []string{"function"})
+
+// HTTPRequestDurationSeconds observes end-to-end HTTP request latency,
+// labeled by method and route.
+var HTTPRequestDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name: "http_request_duration_seconds",
+	Help: "Duration of HTTP requests, labeled by method and route.",
+}, []string{"method", "route"})
+
+func init() {
+	prometheus.MustRegister(
+		SearchRequestsTotal,
+		SearchDurationSeconds,
+		CacheSize,
+		CacheHitsTotal,
+		CacheMissesTotal,
+		FFICallDurationSeconds,
+		HTTPRequestDurationSeconds,
+	)
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Middleware is a `chi`-compatible middleware that records
+// `HTTPRequestDurationSeconds` for every request, labeled by method and the
+// matched route pattern (not the raw path, to keep cardinality bounded).
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		next.ServeHTTP(w, r)
+
+		route := "unknown"
+		if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil {
+			if pattern := routeCtx.RoutePattern(); pattern != "" {
+				route = pattern
+			}
+		}
+
+		HTTPRequestDurationSeconds.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}